@@ -0,0 +1,123 @@
+// Command controller runs the Aerospike Code Challenge controller: it
+// applies desired cluster state and keeps watching Pods for as long as it
+// holds the leader election lease, so multiple replicas can run safely.
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+	"k8s.io/client-go/util/homedir"
+
+	"github.com/saholman/aerospike-code-challenge/pkg/controller"
+	"github.com/saholman/aerospike-code-challenge/pkg/health"
+	"github.com/saholman/aerospike-code-challenge/pkg/reconciler"
+)
+
+const (
+	leaseNamespace = "aerospike"
+	leaseName      = "aerospike-controller"
+	healthAddr     = ":8080"
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	log.Info().Msg("connecting to K8s")
+	config, err := clientcmd.BuildConfigFromFlags("", filepath.Join(homedir.HomeDir(), ".kube", "config"))
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to build config")
+	}
+
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to create clientset from config")
+	}
+
+	dynClient, err := dynamic.NewForConfig(config)
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to create dynamic client from config")
+	}
+
+	podController := controller.New(clientset)
+
+	healthServer := health.NewServer(healthAddr, podController.HasSynced)
+	go func() {
+		if err := healthServer.Start(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatal().Err(err).Msg("health server failed")
+		}
+	}()
+	defer func() {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := healthServer.Shutdown(shutdownCtx); err != nil {
+			log.Error().Err(err).Msg("failed to shut down health server")
+		}
+	}()
+
+	identity, err := os.Hostname()
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to determine leader election identity")
+	}
+
+	lock := &resourcelock.LeaseLock{
+		LeaseMeta: metav1.ObjectMeta{
+			Name:      leaseName,
+			Namespace: leaseNamespace,
+		},
+		Client: clientset.CoordinationV1(),
+		LockConfig: resourcelock.ResourceLockConfig{
+			Identity: identity,
+		},
+	}
+
+	leaderelection.RunOrDie(ctx, leaderelection.LeaderElectionConfig{
+		Lock:            lock,
+		ReleaseOnCancel: true,
+		LeaseDuration:   15 * time.Second,
+		RenewDeadline:   10 * time.Second,
+		RetryPeriod:     2 * time.Second,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(ctx context.Context) {
+				log.Info().Str("identity", identity).Msg("started leading")
+
+				// The one-shot desired-state apply runs alongside the
+				// long-running pod controller rather than gating it, so a
+				// failure or slow apply can't hold the controller (and its
+				// readiness) back.
+				go func() {
+					if err := reconciler.Run(ctx, clientset, dynClient); err != nil {
+						log.Error().Err(err).Msg("failed to apply desired state")
+					}
+				}()
+
+				if err := podController.Run(ctx, 2); err != nil {
+					log.Error().Err(err).Msg("pod controller exited with error")
+				}
+			},
+			OnStoppedLeading: func() {
+				log.Info().Str("identity", identity).Msg("stopped leading")
+			},
+			OnNewLeader: func(leaderIdentity string) {
+				if leaderIdentity == identity {
+					return
+				}
+				log.Info().Str("leader", leaderIdentity).Msg("observed new leader")
+			},
+		},
+	})
+}
@@ -0,0 +1,195 @@
+// Package apply reconciles a list of desired manifests against the
+// cluster using server-side apply, and reports a per-object outcome so
+// callers (and their tests) can assert on individual results.
+package apply
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/saholman/aerospike-code-challenge/pkg/kubeutil"
+)
+
+// FieldManager identifies this controller's writes in server-side apply
+// field ownership metadata.
+const FieldManager = "aerospike-controller"
+
+// Outcome describes what happened to a single manifest during Reconcile.
+type Outcome string
+
+const (
+	Created   Outcome = "created"
+	Updated   Outcome = "updated"
+	Unchanged Outcome = "unchanged"
+	Failed    Outcome = "failed"
+)
+
+// Result records the outcome of applying a single manifest.
+type Result struct {
+	GVK       schema.GroupVersionKind
+	Namespace string
+	Name      string
+	Outcome   Outcome
+	Err       error
+}
+
+// kindToResource maps the Kinds this applier understands to their plural
+// resource name, since we apply via the dynamic client rather than a
+// RESTMapper.
+var kindToResource = map[string]string{
+	"Pod":        "pods",
+	"Deployment": "deployments",
+	"Service":    "services",
+	"ConfigMap":  "configmaps",
+	"Namespace":  "namespaces",
+}
+
+// Reconcile applies each manifest via server-side apply and returns one
+// Result per manifest, in order. A failure applying one manifest does not
+// stop the others from being attempted.
+//
+// clientset is accepted to keep Reconcile's signature consistent with the
+// rest of this package's callers, which all thread both clients through;
+// Reconcile itself only needs dynClient, since every object it handles is
+// applied generically as unstructured data and it doesn't wait on any
+// object's readiness.
+func Reconcile(ctx context.Context, clientset kubernetes.Interface, dynClient dynamic.Interface, manifests []*unstructured.Unstructured) []Result {
+	results := make([]Result, 0, len(manifests))
+	for _, desired := range manifests {
+		results = append(results, reconcileOne(ctx, dynClient, desired))
+	}
+	return results
+}
+
+func reconcileOne(ctx context.Context, dynClient dynamic.Interface, desired *unstructured.Unstructured) Result {
+	gvk := desired.GroupVersionKind()
+	result := Result{
+		GVK:       gvk,
+		Namespace: desired.GetNamespace(),
+		Name:      desired.GetName(),
+	}
+
+	gvr, ok := resourceForKind(gvk)
+	if !ok {
+		result.Outcome = Failed
+		result.Err = fmt.Errorf("unsupported kind %q", gvk.Kind)
+		return result
+	}
+
+	resourceClient := namespacedResource(dynClient, gvr, desired.GetNamespace())
+
+	existing, err := kubeutil.GetWithRetry(ctx, func(ctx context.Context) (*unstructured.Unstructured, error) {
+		return resourceClient.Get(ctx, desired.GetName(), metav1.GetOptions{})
+	})
+	if err != nil && !apierrors.IsNotFound(err) {
+		result.Outcome = Failed
+		result.Err = fmt.Errorf("failed to get existing object: %w", err)
+		return result
+	}
+
+	// Server-side apply can create an object that doesn't exist yet, but
+	// the object must be present for a field-manager Patch to take effect
+	// against some API servers (and against the fake clientset used in
+	// tests), so create it directly the first time around.
+	if apierrors.IsNotFound(err) {
+		_, err := kubeutil.CreateWithRetry(ctx, func(ctx context.Context) (*unstructured.Unstructured, error) {
+			return resourceClient.Create(ctx, desired, metav1.CreateOptions{FieldManager: FieldManager})
+		})
+		if err != nil {
+			result.Outcome = Failed
+			result.Err = fmt.Errorf("failed to create object: %w", err)
+			return result
+		}
+
+		result.Outcome = Created
+		return result
+	}
+
+	data, err := json.Marshal(desired)
+	if err != nil {
+		result.Outcome = Failed
+		result.Err = fmt.Errorf("failed to marshal manifest: %w", err)
+		return result
+	}
+
+	force := true
+	applied, err := kubeutil.UpdateWithRetry(ctx, func(ctx context.Context) (*unstructured.Unstructured, error) {
+		return resourceClient.Patch(ctx, desired.GetName(), types.ApplyPatchType, data, metav1.PatchOptions{
+			FieldManager: FieldManager,
+			Force:        &force,
+		})
+	})
+	if err != nil {
+		result.Outcome = Failed
+		result.Err = fmt.Errorf("failed to apply object: %w", err)
+		return result
+	}
+
+	if specEqual(existing, applied) {
+		result.Outcome = Unchanged
+	} else {
+		result.Outcome = Updated
+	}
+	return result
+}
+
+// specEqual reports whether existing and applied carry the same desired
+// state, so Reconcile can distinguish a no-op apply from a real update.
+func specEqual(existing, applied *unstructured.Unstructured) bool {
+	switch applied.GetKind() {
+	case "ConfigMap":
+		return reflect.DeepEqual(existing.Object["data"], applied.Object["data"]) &&
+			reflect.DeepEqual(existing.Object["binaryData"], applied.Object["binaryData"])
+	case "Namespace":
+		return reflect.DeepEqual(existing.GetLabels(), applied.GetLabels()) &&
+			reflect.DeepEqual(existing.GetAnnotations(), applied.GetAnnotations())
+	default:
+		return reflect.DeepEqual(existing.Object["spec"], applied.Object["spec"])
+	}
+}
+
+func resourceForKind(gvk schema.GroupVersionKind) (schema.GroupVersionResource, bool) {
+	resource, ok := kindToResource[gvk.Kind]
+	if !ok {
+		return schema.GroupVersionResource{}, false
+	}
+	return gvk.GroupVersion().WithResource(resource), true
+}
+
+// Delete removes each manifest's corresponding object, ignoring
+// not-found errors so Delete is safe to call more than once.
+func Delete(ctx context.Context, dynClient dynamic.Interface, manifests []*unstructured.Unstructured) error {
+	for _, desired := range manifests {
+		gvr, ok := resourceForKind(desired.GroupVersionKind())
+		if !ok {
+			return fmt.Errorf("unsupported kind %q", desired.GetKind())
+		}
+
+		resourceClient := namespacedResource(dynClient, gvr, desired.GetNamespace())
+		err := kubeutil.DeleteWithRetry(ctx, func(ctx context.Context) error {
+			return resourceClient.Delete(ctx, desired.GetName(), metav1.DeleteOptions{})
+		})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete %s %s: %w", desired.GetKind(), desired.GetName(), err)
+		}
+	}
+	return nil
+}
+
+func namespacedResource(dynClient dynamic.Interface, gvr schema.GroupVersionResource, namespace string) dynamic.ResourceInterface {
+	resourceClient := dynClient.Resource(gvr)
+	if namespace == "" {
+		return resourceClient
+	}
+	return resourceClient.Namespace(namespace)
+}
@@ -0,0 +1,140 @@
+package apply_test
+
+import (
+	"context"
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/saholman/aerospike-code-challenge/pkg/apply"
+)
+
+var configMapsGVR = schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+
+// newDynClient builds a fake dynamic client with a reactor standing in for
+// server-side apply: the fake tracker's built-in ApplyPatchType handling
+// runs a strategic-merge patch keyed off Go struct tags, which doesn't
+// understand unstructured.Unstructured objects. Real API servers apply
+// field-manager patches server-side instead, so this replaces the
+// not-applicable emulation with a plain replace of the stored object.
+func newDynClient() dynamic.Interface {
+	scheme := runtime.NewScheme()
+	Expect(v1.AddToScheme(scheme)).To(Succeed())
+
+	dynClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(scheme, map[schema.GroupVersionResource]string{
+		configMapsGVR: "ConfigMapList",
+	})
+	dynClient.PrependReactor("patch", "*", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		patchAction := action.(k8stesting.PatchActionImpl)
+		if patchAction.GetPatchType() != types.ApplyPatchType {
+			return false, nil, nil
+		}
+
+		applied := &unstructured.Unstructured{}
+		if err := json.Unmarshal(patchAction.GetPatch(), &applied.Object); err != nil {
+			return true, nil, err
+		}
+		if err := dynClient.Tracker().Update(patchAction.GetResource(), applied, patchAction.GetNamespace()); err != nil {
+			return true, nil, err
+		}
+		return true, applied, nil
+	})
+	return dynClient
+}
+
+func configMap(name string, data map[string]interface{}) *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata": map[string]interface{}{
+				"name":      name,
+				"namespace": "aerospike",
+			},
+			"data": data,
+		},
+	}
+}
+
+var _ = Describe("Reconcile", func() {
+	var (
+		clientset kubernetes.Interface
+		dynClient dynamic.Interface
+		ctx       context.Context
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		clientset = fake.NewSimpleClientset()
+		dynClient = newDynClient()
+	})
+
+	When("a manifest doesn't exist yet", func() {
+		It("creates it", func() {
+			results := apply.Reconcile(ctx, clientset, dynClient, []*unstructured.Unstructured{
+				configMap("my-config", map[string]interface{}{"key": "value"}),
+			})
+			Expect(results).To(HaveLen(1))
+			Expect(results[0].Err).ShouldNot(HaveOccurred())
+			Expect(results[0].Outcome).To(Equal(apply.Created))
+		})
+	})
+
+	When("a manifest is re-applied unchanged", func() {
+		It("reports it as unchanged", func() {
+			manifests := []*unstructured.Unstructured{configMap("my-config", map[string]interface{}{"key": "value"})}
+			apply.Reconcile(ctx, clientset, dynClient, manifests)
+
+			results := apply.Reconcile(ctx, clientset, dynClient, manifests)
+			Expect(results).To(HaveLen(1))
+			Expect(results[0].Err).ShouldNot(HaveOccurred())
+			Expect(results[0].Outcome).To(Equal(apply.Unchanged))
+		})
+	})
+
+	When("a manifest's spec changes", func() {
+		It("reports it as updated", func() {
+			apply.Reconcile(ctx, clientset, dynClient, []*unstructured.Unstructured{
+				configMap("my-config", map[string]interface{}{"key": "value"}),
+			})
+
+			results := apply.Reconcile(ctx, clientset, dynClient, []*unstructured.Unstructured{
+				configMap("my-config", map[string]interface{}{"key": "other-value"}),
+			})
+			Expect(results).To(HaveLen(1))
+			Expect(results[0].Err).ShouldNot(HaveOccurred())
+			Expect(results[0].Outcome).To(Equal(apply.Updated))
+		})
+	})
+
+	When("a manifest's kind isn't supported", func() {
+		It("reports it as failed", func() {
+			manifest := &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"apiVersion": "v1",
+					"kind":       "Secret",
+					"metadata": map[string]interface{}{
+						"name":      "my-secret",
+						"namespace": "aerospike",
+					},
+				},
+			}
+
+			results := apply.Reconcile(ctx, clientset, dynClient, []*unstructured.Unstructured{manifest})
+			Expect(results).To(HaveLen(1))
+			Expect(results[0].Err).Should(HaveOccurred())
+			Expect(results[0].Outcome).To(Equal(apply.Failed))
+		})
+	})
+})
@@ -0,0 +1,204 @@
+// Package controller implements a workqueue-backed pod controller built
+// on top of a client-go SharedInformerFactory. Informer event handlers
+// only enqueue namespaced keys; all actual work happens in reconcile, so
+// reconciliation stays idempotent and safe to run from multiple replicas
+// behind a leader election lease.
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+)
+
+// Controller reconciles Pod objects observed through a shared informer.
+type Controller struct {
+	clientset       kubernetes.Interface
+	informerFactory informers.SharedInformerFactory
+	podLister       corelisters.PodLister
+	podsSynced      cache.InformerSynced
+	eventLister     corelisters.EventLister
+	eventsSynced    cache.InformerSynced
+	recorder        PodEventRecorder
+	queue           workqueue.RateLimitingInterface
+}
+
+// New builds a Controller that watches Pods across all namespaces using
+// the given clientset. Call Run to start processing.
+func New(clientset kubernetes.Interface) *Controller {
+	return NewWithRecorder(clientset, zerologPodEventRecorder{})
+}
+
+// NewWithRecorder builds a Controller like New, but reports correlated pod
+// events through recorder instead of the package default. It exists so
+// tests can inject a recorder that captures events instead of logging them.
+func NewWithRecorder(clientset kubernetes.Interface, recorder PodEventRecorder) *Controller {
+	informerFactory := informers.NewSharedInformerFactory(clientset, 0)
+	podInformer := informerFactory.Core().V1().Pods()
+	eventInformer := informerFactory.Core().V1().Events()
+
+	c := &Controller{
+		clientset:       clientset,
+		informerFactory: informerFactory,
+		podLister:       podInformer.Lister(),
+		podsSynced:      podInformer.Informer().HasSynced,
+		eventLister:     eventInformer.Lister(),
+		eventsSynced:    eventInformer.Informer().HasSynced,
+		recorder:        recorder,
+		queue:           workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+	}
+
+	podInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.onPodAdd,
+		UpdateFunc: c.onPodUpdate,
+		DeleteFunc: c.onPodDelete,
+	})
+	eventInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: c.onEventAdd,
+	})
+
+	return c
+}
+
+// HasSynced reports whether the pod and event informer caches have
+// completed their initial sync. It is safe to call concurrently and is
+// suitable for wiring into a readiness probe.
+func (c *Controller) HasSynced() bool {
+	return c.podsSynced() && c.eventsSynced()
+}
+
+// Run starts the informer and blocks processing the workqueue with the
+// given number of worker goroutines until ctx is cancelled.
+func (c *Controller) Run(ctx context.Context, workers int) error {
+	defer c.queue.ShutDown()
+
+	log.Info().Msg("starting pod controller")
+	c.informerFactory.Start(ctx.Done())
+
+	if !cache.WaitForCacheSync(ctx.Done(), c.podsSynced, c.eventsSynced) {
+		return fmt.Errorf("failed to wait for pod informer cache to sync")
+	}
+
+	for i := 0; i < workers; i++ {
+		go c.runWorker(ctx)
+	}
+
+	log.Info().Int("workers", workers).Msg("pod controller started")
+	<-ctx.Done()
+	log.Info().Msg("stopping pod controller")
+	return nil
+}
+
+func (c *Controller) runWorker(ctx context.Context) {
+	for c.processNextWorkItem(ctx) {
+	}
+}
+
+func (c *Controller) processNextWorkItem(ctx context.Context) bool {
+	key, shutdown := c.queue.Get()
+	if shutdown {
+		return false
+	}
+	defer c.queue.Done(key)
+
+	if err := c.reconcile(ctx, key.(string)); err != nil {
+		log.Error().Err(err).Str("key", key.(string)).Msg("failed to reconcile pod, requeueing")
+		c.queue.AddRateLimited(key)
+		return true
+	}
+
+	c.queue.Forget(key)
+	return true
+}
+
+// reconcile brings observed state for the pod named by key in line with
+// expectations. It is idempotent: it only reads from the informer cache
+// and logs the pod's current status, so running it any number of times,
+// from any number of replicas, converges to the same outcome.
+func (c *Controller) reconcile(ctx context.Context, key string) error {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return fmt.Errorf("invalid resource key %q: %w", key, err)
+	}
+
+	pod, err := c.podLister.Pods(namespace).Get(name)
+	if apierrors.IsNotFound(err) {
+		log.Info().Str("namespace", namespace).Str("name", name).Msg("pod no longer exists")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get pod %s/%s: %w", namespace, name, err)
+	}
+
+	log.Info().
+		Str("namespace", pod.Namespace).
+		Str("name", pod.Name).
+		Str("phase", string(pod.Status.Phase)).
+		Msg("reconciled pod")
+	return nil
+}
+
+func (c *Controller) onPodAdd(obj any) {
+	c.enqueue(obj)
+}
+
+func (c *Controller) onPodUpdate(oldObj, newObj any) {
+	oldPod, oldOk := oldObj.(*v1.Pod)
+	newPod, newOk := newObj.(*v1.Pod)
+	if oldOk && newOk && oldPod.Status.Phase != newPod.Status.Phase {
+		log.Info().
+			Str("namespace", newPod.Namespace).
+			Str("name", newPod.Name).
+			Str("from", string(oldPod.Status.Phase)).
+			Str("to", string(newPod.Status.Phase)).
+			Interface("containers", containerStates(newPod)).
+			Msg("pod phase transition")
+	}
+	c.enqueue(newObj)
+}
+
+func (c *Controller) onPodDelete(obj any) {
+	c.enqueue(obj)
+}
+
+func (c *Controller) enqueue(obj any) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to build key for pod event")
+		return
+	}
+	c.queue.Add(key)
+}
+
+// onEventAdd correlates a newly observed Event to the Pod it was raised
+// against and hands the pair to the recorder. Events are matched by
+// namespace/name through the pod lister and then confirmed by UID, so a
+// stale event for a pod that has since been deleted and recreated under the
+// same name is not misattributed to the new pod.
+func (c *Controller) onEventAdd(obj any) {
+	event, ok := obj.(*v1.Event)
+	if !ok {
+		return
+	}
+	if event.InvolvedObject.Kind != "Pod" {
+		return
+	}
+
+	pod, err := c.podLister.Pods(event.InvolvedObject.Namespace).Get(event.InvolvedObject.Name)
+	if err != nil {
+		return
+	}
+	if pod.UID != event.InvolvedObject.UID {
+		return
+	}
+
+	c.recorder.RecordPodEvent(pod, event)
+}
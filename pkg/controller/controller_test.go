@@ -0,0 +1,132 @@
+package controller_test
+
+import (
+	"context"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/saholman/aerospike-code-challenge/pkg/controller"
+)
+
+// fakeRecorder captures correlated pod events instead of logging them, so
+// tests can assert on what the controller observed.
+type fakeRecorder struct {
+	mu     sync.Mutex
+	events []recordedEvent
+}
+
+type recordedEvent struct {
+	podName string
+	phase   v1.PodPhase
+	reason  string
+}
+
+func (r *fakeRecorder) RecordPodEvent(pod *v1.Pod, event *v1.Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, recordedEvent{
+		podName: pod.Name,
+		phase:   pod.Status.Phase,
+		reason:  event.Reason,
+	})
+}
+
+func (r *fakeRecorder) Events() []recordedEvent {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]recordedEvent(nil), r.events...)
+}
+
+var _ = Describe("Controller", func() {
+	It("correlates events to the pod they were raised against by UID", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		pod := &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "hello-world",
+				Namespace: "aerospike",
+				UID:       types.UID("pod-uid-1"),
+			},
+			Status: v1.PodStatus{Phase: v1.PodRunning},
+		}
+		clientset := fake.NewSimpleClientset(pod)
+		recorder := &fakeRecorder{}
+		podController := controller.NewWithRecorder(clientset, recorder)
+
+		go func() {
+			_ = podController.Run(ctx, 1)
+		}()
+		Eventually(podController.HasSynced).Should(BeTrue())
+
+		event := &v1.Event{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "hello-world.scheduled",
+				Namespace: "aerospike",
+			},
+			InvolvedObject: v1.ObjectReference{
+				Kind:      "Pod",
+				Namespace: "aerospike",
+				Name:      "hello-world",
+				UID:       pod.UID,
+			},
+			Reason:  "Started",
+			Message: "Started container main",
+		}
+		_, err := clientset.CoreV1().Events("aerospike").Create(ctx, event, metav1.CreateOptions{})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		Eventually(recorder.Events).Should(ContainElement(recordedEvent{
+			podName: "hello-world",
+			phase:   v1.PodRunning,
+			reason:  "Started",
+		}))
+	})
+
+	It("ignores events for a pod that has since been recreated under the same name", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		pod := &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "hello-world",
+				Namespace: "aerospike",
+				UID:       types.UID("current-pod-uid"),
+			},
+			Status: v1.PodStatus{Phase: v1.PodRunning},
+		}
+		clientset := fake.NewSimpleClientset(pod)
+		recorder := &fakeRecorder{}
+		podController := controller.NewWithRecorder(clientset, recorder)
+
+		go func() {
+			_ = podController.Run(ctx, 1)
+		}()
+		Eventually(podController.HasSynced).Should(BeTrue())
+
+		staleEvent := &v1.Event{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "hello-world.killing",
+				Namespace: "aerospike",
+			},
+			InvolvedObject: v1.ObjectReference{
+				Kind:      "Pod",
+				Namespace: "aerospike",
+				Name:      "hello-world",
+				UID:       types.UID("stale-pod-uid"),
+			},
+			Reason:  "Killing",
+			Message: "Stopping container main",
+		}
+		_, err := clientset.CoreV1().Events("aerospike").Create(ctx, staleEvent, metav1.CreateOptions{})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		Consistently(recorder.Events).Should(BeEmpty())
+	})
+})
@@ -0,0 +1,54 @@
+package controller
+
+import (
+	"github.com/rs/zerolog/log"
+	v1 "k8s.io/api/core/v1"
+)
+
+// PodEventRecorder observes a Kubernetes Event correlated to the Pod it was
+// raised against, so callers (and tests) can assert on what the controller
+// saw without scraping log output.
+type PodEventRecorder interface {
+	RecordPodEvent(pod *v1.Pod, event *v1.Event)
+}
+
+// zerologPodEventRecorder is the PodEventRecorder used in production: it
+// logs the pod's current phase and container states alongside the event
+// that was raised against it.
+type zerologPodEventRecorder struct{}
+
+func (zerologPodEventRecorder) RecordPodEvent(pod *v1.Pod, event *v1.Event) {
+	log.Info().
+		Str("namespace", pod.Namespace).
+		Str("name", pod.Name).
+		Str("phase", string(pod.Status.Phase)).
+		Interface("containers", containerStates(pod)).
+		Str("eventReason", event.Reason).
+		Str("eventMessage", event.Message).
+		Msg("observed pod event")
+}
+
+// containerState summarizes the parts of a container's current state that
+// are useful for diagnosing why a pod isn't ready: why it's waiting, or the
+// exit code it last terminated with.
+type containerState struct {
+	Name           string `json:"name"`
+	WaitingReason  string `json:"waitingReason,omitempty"`
+	TerminatedExit *int32 `json:"terminatedExitCode,omitempty"`
+}
+
+func containerStates(pod *v1.Pod) []containerState {
+	states := make([]containerState, 0, len(pod.Status.ContainerStatuses))
+	for _, cs := range pod.Status.ContainerStatuses {
+		state := containerState{Name: cs.Name}
+		if cs.State.Waiting != nil {
+			state.WaitingReason = cs.State.Waiting.Reason
+		}
+		if cs.State.Terminated != nil {
+			exitCode := cs.State.Terminated.ExitCode
+			state.TerminatedExit = &exitCode
+		}
+		states = append(states, state)
+	}
+	return states
+}
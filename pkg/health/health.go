@@ -0,0 +1,49 @@
+// Package health provides a minimal HTTP server exposing liveness and
+// readiness probes for the controller binary.
+package health
+
+import (
+	"context"
+	"net/http"
+)
+
+// Server serves /healthz and /readyz over HTTP.
+type Server struct {
+	httpServer *http.Server
+}
+
+// NewServer builds a Server listening on addr. readyFunc is consulted on
+// every /readyz request; the probe reports 200 only while it returns true.
+func NewServer(addr string, readyFunc func() bool) *Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, _ *http.Request) {
+		if !readyFunc() {
+			http.Error(w, "not ready", http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	return &Server{
+		httpServer: &http.Server{
+			Addr:    addr,
+			Handler: mux,
+		},
+	}
+}
+
+// Start begins serving and blocks until the server stops. It always
+// returns a non-nil error, matching net/http.Server.ListenAndServe, except
+// after a graceful Shutdown where it returns http.ErrServerClosed.
+func (s *Server) Start() error {
+	return s.httpServer.ListenAndServe()
+}
+
+// Shutdown gracefully stops the server, waiting for in-flight requests to
+// complete or ctx to be done.
+func (s *Server) Shutdown(ctx context.Context) error {
+	return s.httpServer.Shutdown(ctx)
+}
@@ -0,0 +1,67 @@
+package kube
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/saholman/aerospike-code-challenge/pkg/kubeutil"
+)
+
+// EnsureNamespace makes sure a namespace named name exists. If it's
+// missing, EnsureNamespace creates it with the given labels and
+// annotations. If it already exists, EnsureNamespace patches its labels
+// and annotations to match rather than failing, so repeated runs and
+// shared-cluster testing are safe.
+func EnsureNamespace(ctx context.Context, clientset kubernetes.Interface, name string, labels, annotations map[string]string) (*v1.Namespace, error) {
+	existing, err := kubeutil.GetWithRetry(ctx, func(ctx context.Context) (*v1.Namespace, error) {
+		return clientset.CoreV1().Namespaces().Get(ctx, name, metav1.GetOptions{})
+	})
+	if apierrors.IsNotFound(err) {
+		ns := &v1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Labels:      labels,
+				Annotations: annotations,
+			},
+		}
+		created, err := kubeutil.CreateWithRetry(ctx, func(ctx context.Context) (*v1.Namespace, error) {
+			return clientset.CoreV1().Namespaces().Create(ctx, ns, metav1.CreateOptions{})
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create namespace %s: %w", name, err)
+		}
+		return created, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get namespace %s: %w", name, err)
+	}
+
+	if len(labels) == 0 && len(annotations) == 0 {
+		return existing, nil
+	}
+
+	patch, err := json.Marshal(map[string]any{
+		"metadata": map[string]any{
+			"labels":      labels,
+			"annotations": annotations,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to build namespace patch: %w", err)
+	}
+
+	patched, err := kubeutil.UpdateWithRetry(ctx, func(ctx context.Context) (*v1.Namespace, error) {
+		return clientset.CoreV1().Namespaces().Patch(ctx, name, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to patch namespace %s: %w", name, err)
+	}
+	return patched, nil
+}
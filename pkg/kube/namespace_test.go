@@ -0,0 +1,58 @@
+package kube_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/saholman/aerospike-code-challenge/pkg/kube"
+)
+
+var _ = Describe("EnsureNamespace", func() {
+	var ctx context.Context
+
+	BeforeEach(func() {
+		ctx = context.Background()
+	})
+
+	When("the namespace does not exist", func() {
+		It("creates it with the given labels and annotations", func() {
+			clientset := fake.NewSimpleClientset()
+
+			ns, err := kube.EnsureNamespace(ctx, clientset, "aerospike", map[string]string{"team": "data"}, map[string]string{"owner": "aerospike"})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(ns.Labels).To(HaveKeyWithValue("team", "data"))
+			Expect(ns.Annotations).To(HaveKeyWithValue("owner", "aerospike"))
+
+			fromAPI, err := clientset.CoreV1().Namespaces().Get(ctx, "aerospike", metav1.GetOptions{})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(fromAPI.Name).To(Equal("aerospike"))
+		})
+	})
+
+	When("the namespace already exists", func() {
+		It("succeeds instead of returning an AlreadyExists error", func() {
+			clientset := fake.NewSimpleClientset(&v1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{Name: "aerospike"},
+			})
+
+			ns, err := kube.EnsureNamespace(ctx, clientset, "aerospike", nil, nil)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(ns.Name).To(Equal("aerospike"))
+		})
+
+		It("patches labels and annotations onto the existing namespace", func() {
+			clientset := fake.NewSimpleClientset(&v1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{Name: "aerospike"},
+			})
+
+			ns, err := kube.EnsureNamespace(ctx, clientset, "aerospike", map[string]string{"team": "data"}, nil)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(ns.Labels).To(HaveKeyWithValue("team", "data"))
+		})
+	})
+})
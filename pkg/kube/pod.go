@@ -0,0 +1,197 @@
+// Package kube provides small, reusable helpers for building and
+// managing Kubernetes objects on top of client-go.
+package kube
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/saholman/aerospike-code-challenge/pkg/kubeutil"
+)
+
+// DefaultReadyTimeout bounds how long CreatePod will wait for a pod to
+// become ready before giving up.
+const DefaultReadyTimeout = 2 * time.Minute
+
+// PodOptions describes the pod CreatePod should build. Ports are given as
+// "hostPort:containerPort" pairs. Volumes maps a volume name to the path
+// it should be mounted at in every container; a volume backed by
+// ClaimName uses that PersistentVolumeClaim, otherwise it's an emptyDir.
+//
+// WaitForReady opts into blocking CreatePod on the pod's PodReady
+// condition. Leave it false for run-to-completion pods (the PodReady
+// condition is never set for a pod that exits on its own), and set it
+// true for long-running pods a caller needs to be serving before it
+// proceeds.
+type PodOptions struct {
+	Namespace      string
+	GenerateName   string
+	Image          string
+	Command        []string
+	Env            map[string]string
+	Ports          []string
+	Volumes        map[string]Volume
+	Labels         map[string]string
+	ServiceAccount string
+	WaitForReady   bool
+}
+
+// Volume describes where a pod volume should be mounted and what backs
+// it.
+type Volume struct {
+	MountPath string
+	ClaimName string
+}
+
+// CreatePod builds a pod from opts and submits it. If opts.WaitForReady is
+// set, CreatePod also waits up to DefaultReadyTimeout for it to become
+// ready (or for ctx to time out, whichever comes first) before returning.
+func CreatePod(ctx context.Context, clientset kubernetes.Interface, opts PodOptions) (*v1.Pod, error) {
+	pod, err := buildPod(opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build pod spec: %w", err)
+	}
+
+	created, err := kubeutil.CreateWithRetry(ctx, func(ctx context.Context) (*v1.Pod, error) {
+		return clientset.CoreV1().Pods(opts.Namespace).Create(ctx, pod, metav1.CreateOptions{})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create pod: %w", err)
+	}
+
+	if !opts.WaitForReady {
+		return created, nil
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, DefaultReadyTimeout)
+	defer cancel()
+	return WaitForPodReady(waitCtx, clientset, created.Namespace, created.Name)
+}
+
+// WaitForPodReady polls the named pod until its PodReady condition is
+// true or ctx is done, returning the last observed pod either way.
+func WaitForPodReady(ctx context.Context, clientset kubernetes.Interface, namespace, name string) (*v1.Pod, error) {
+	var pod *v1.Pod
+	err := wait.PollImmediateUntilWithContext(ctx, time.Second, func(ctx context.Context) (bool, error) {
+		p, err := clientset.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		pod = p
+		return isPodReady(p), nil
+	})
+	return pod, err
+}
+
+func isPodReady(pod *v1.Pod) bool {
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == v1.PodReady {
+			return cond.Status == v1.ConditionTrue
+		}
+	}
+	return false
+}
+
+func buildPod(opts PodOptions) (*v1.Pod, error) {
+	ports, err := parsePorts(opts.Ports)
+	if err != nil {
+		return nil, err
+	}
+
+	volumes, mounts := buildVolumes(opts.Volumes)
+
+	container := v1.Container{
+		Name:         "main",
+		Image:        opts.Image,
+		Command:      opts.Command,
+		Env:          buildEnv(opts.Env),
+		Ports:        ports,
+		VolumeMounts: mounts,
+	}
+
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: opts.GenerateName,
+			Namespace:    opts.Namespace,
+			Labels:       opts.Labels,
+		},
+		Spec: v1.PodSpec{
+			ServiceAccountName: opts.ServiceAccount,
+			Containers:         []v1.Container{container},
+			Volumes:            volumes,
+			RestartPolicy:      v1.RestartPolicyNever,
+		},
+	}, nil
+}
+
+func buildEnv(env map[string]string) []v1.EnvVar {
+	if len(env) == 0 {
+		return nil
+	}
+	vars := make([]v1.EnvVar, 0, len(env))
+	for name, value := range env {
+		vars = append(vars, v1.EnvVar{Name: name, Value: value})
+	}
+	return vars
+}
+
+// parsePorts turns "hostPort:containerPort" pairs into ContainerPorts.
+func parsePorts(specs []string) ([]v1.ContainerPort, error) {
+	if len(specs) == 0 {
+		return nil, nil
+	}
+
+	ports := make([]v1.ContainerPort, 0, len(specs))
+	for _, spec := range specs {
+		hostPort, containerPort, ok := strings.Cut(spec, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid port %q, expected \"hostPort:containerPort\"", spec)
+		}
+
+		host, err := strconv.Atoi(hostPort)
+		if err != nil {
+			return nil, fmt.Errorf("invalid host port %q: %w", hostPort, err)
+		}
+		container, err := strconv.Atoi(containerPort)
+		if err != nil {
+			return nil, fmt.Errorf("invalid container port %q: %w", containerPort, err)
+		}
+
+		ports = append(ports, v1.ContainerPort{
+			HostPort:      int32(host),
+			ContainerPort: int32(container),
+		})
+	}
+	return ports, nil
+}
+
+func buildVolumes(volumes map[string]Volume) ([]v1.Volume, []v1.VolumeMount) {
+	if len(volumes) == 0 {
+		return nil, nil
+	}
+
+	podVolumes := make([]v1.Volume, 0, len(volumes))
+	mounts := make([]v1.VolumeMount, 0, len(volumes))
+	for name, volume := range volumes {
+		source := v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{}}
+		if volume.ClaimName != "" {
+			source = v1.VolumeSource{
+				PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{
+					ClaimName: volume.ClaimName,
+				},
+			}
+		}
+
+		podVolumes = append(podVolumes, v1.Volume{Name: name, VolumeSource: source})
+		mounts = append(mounts, v1.VolumeMount{Name: name, MountPath: volume.MountPath})
+	}
+	return podVolumes, mounts
+}
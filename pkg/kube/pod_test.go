@@ -0,0 +1,133 @@
+package kube_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/saholman/aerospike-code-challenge/pkg/kube"
+)
+
+// markFirstPodReady watches for a pod to show up in the given namespace
+// and flips it to Ready, standing in for the kubelet that would normally
+// do so against a real cluster.
+func markFirstPodReady(ctx context.Context, clientset kubernetes.Interface, namespace string) {
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			default:
+			}
+
+			pods, err := clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+			if err == nil && len(pods.Items) > 0 {
+				pod := pods.Items[0].DeepCopy()
+				pod.Status.Conditions = []v1.PodCondition{{Type: v1.PodReady, Status: v1.ConditionTrue}}
+				_, _ = clientset.CoreV1().Pods(namespace).UpdateStatus(ctx, pod, metav1.UpdateOptions{})
+				return
+			}
+			time.Sleep(20 * time.Millisecond)
+		}
+	}()
+}
+
+var _ = Describe("CreatePod", func() {
+	var (
+		clientset kubernetes.Interface
+		ctx       context.Context
+		cancel    context.CancelFunc
+	)
+
+	BeforeEach(func() {
+		clientset = fake.NewSimpleClientset()
+		ctx, cancel = context.WithTimeout(context.Background(), 5*time.Second)
+	})
+
+	AfterEach(func() {
+		cancel()
+	})
+
+	When("volumes are configured", func() {
+		It("mounts PVC-backed and emptyDir volumes", func() {
+			markFirstPodReady(ctx, clientset, "aerospike")
+
+			pod, err := kube.CreatePod(ctx, clientset, kube.PodOptions{
+				Namespace:    "aerospike",
+				GenerateName: "worker-",
+				Image:        "busybox",
+				WaitForReady: true,
+				Volumes: map[string]kube.Volume{
+					"data":    {MountPath: "/data", ClaimName: "data-pvc"},
+					"scratch": {MountPath: "/scratch"},
+				},
+			})
+			Expect(err).ShouldNot(HaveOccurred())
+
+			volumesByName := map[string]v1.Volume{}
+			for _, v := range pod.Spec.Volumes {
+				volumesByName[v.Name] = v
+			}
+			Expect(volumesByName).To(HaveKey("data"))
+			Expect(volumesByName["data"].PersistentVolumeClaim.ClaimName).To(Equal("data-pvc"))
+			Expect(volumesByName).To(HaveKey("scratch"))
+			Expect(volumesByName["scratch"].EmptyDir).NotTo(BeNil())
+
+			mountsByName := map[string]v1.VolumeMount{}
+			for _, m := range pod.Spec.Containers[0].VolumeMounts {
+				mountsByName[m.Name] = m
+			}
+			Expect(mountsByName["data"].MountPath).To(Equal("/data"))
+			Expect(mountsByName["scratch"].MountPath).To(Equal("/scratch"))
+		})
+	})
+
+	When("ports are configured", func() {
+		It("parses hostPort:containerPort pairs", func() {
+			markFirstPodReady(ctx, clientset, "aerospike")
+
+			pod, err := kube.CreatePod(ctx, clientset, kube.PodOptions{
+				Namespace:    "aerospike",
+				GenerateName: "worker-",
+				Image:        "busybox",
+				WaitForReady: true,
+				Ports:        []string{"8080:80", "9090:9090"},
+			})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(pod.Spec.Containers[0].Ports).To(ConsistOf(
+				v1.ContainerPort{HostPort: 8080, ContainerPort: 80},
+				v1.ContainerPort{HostPort: 9090, ContainerPort: 9090},
+			))
+		})
+
+		It("rejects a malformed port spec", func() {
+			_, err := kube.CreatePod(ctx, clientset, kube.PodOptions{
+				Namespace:    "aerospike",
+				GenerateName: "worker-",
+				Image:        "busybox",
+				Ports:        []string{"not-a-port"},
+			})
+			Expect(err).Should(HaveOccurred())
+		})
+	})
+
+	When("WaitForReady is left unset", func() {
+		It("returns without waiting for a pod that will never become ready", func() {
+			// A run-to-completion pod never reports PodReady, so
+			// CreatePod must not hang (or time out) waiting for one.
+			pod, err := kube.CreatePod(ctx, clientset, kube.PodOptions{
+				Namespace:    "aerospike",
+				GenerateName: "hello-world-",
+				Image:        "hello-world",
+			})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(pod.Spec.Containers[0].Image).To(Equal("hello-world"))
+		})
+	})
+})
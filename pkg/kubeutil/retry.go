@@ -0,0 +1,110 @@
+// Package kubeutil provides small retry helpers around Kubernetes client
+// calls, so a flaky API server causes a backoff-and-retry instead of
+// crashing the caller.
+package kubeutil
+
+import (
+	"context"
+	"errors"
+	"net"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
+)
+
+// Backoff is the default exponential backoff used by the helpers below.
+// It mirrors retry.DefaultBackoff's shape but is named here so callers of
+// this package don't need to reach into client-go/util/retry directly.
+var Backoff = retry.DefaultBackoff
+
+// IsRetryable reports whether err is a transient error worth retrying:
+// server timeouts, rate limiting, internal errors, and network errors.
+// IsAlreadyExists and IsNotFound are deliberately excluded so callers can
+// act on them immediately instead of burning through a backoff.
+func IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+	if apierrors.IsServerTimeout(err) || apierrors.IsTooManyRequests(err) || apierrors.IsInternalError(err) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// Do retries fn with an exponential backoff as long as it returns a
+// retryable error, stopping early if ctx is done or fn returns a
+// non-retryable error (including IsAlreadyExists/IsNotFound).
+func Do(ctx context.Context, fn func() error) error {
+	var lastErr error
+	err := wait.ExponentialBackoffWithContext(ctx, Backoff, func(ctx context.Context) (bool, error) {
+		err := fn()
+		switch {
+		case err == nil:
+			return true, nil
+		case IsRetryable(err):
+			lastErr = err
+			return false, nil
+		default:
+			return false, err
+		}
+	})
+	if errors.Is(err, wait.ErrWaitTimeout) {
+		err = lastErr
+	}
+	return err
+}
+
+// CreateWithRetry retries create until it succeeds, ctx is done, or it
+// fails with a non-retryable error such as IsAlreadyExists.
+func CreateWithRetry[T any](ctx context.Context, create func(ctx context.Context) (T, error)) (T, error) {
+	var result T
+	err := Do(ctx, func() error {
+		r, err := create(ctx)
+		if err != nil {
+			return err
+		}
+		result = r
+		return nil
+	})
+	return result, err
+}
+
+// GetWithRetry retries get until it succeeds, ctx is done, or it fails
+// with a non-retryable error such as IsNotFound.
+func GetWithRetry[T any](ctx context.Context, get func(ctx context.Context) (T, error)) (T, error) {
+	var result T
+	err := Do(ctx, func() error {
+		r, err := get(ctx)
+		if err != nil {
+			return err
+		}
+		result = r
+		return nil
+	})
+	return result, err
+}
+
+// UpdateWithRetry retries update (a Patch or Update call) until it
+// succeeds, ctx is done, or it fails with a non-retryable error.
+func UpdateWithRetry[T any](ctx context.Context, update func(ctx context.Context) (T, error)) (T, error) {
+	var result T
+	err := Do(ctx, func() error {
+		r, err := update(ctx)
+		if err != nil {
+			return err
+		}
+		result = r
+		return nil
+	})
+	return result, err
+}
+
+// DeleteWithRetry retries del until it succeeds, ctx is done, or it fails
+// with a non-retryable error such as IsNotFound.
+func DeleteWithRetry(ctx context.Context, del func(ctx context.Context) error) error {
+	return Do(ctx, func() error {
+		return del(ctx)
+	})
+}
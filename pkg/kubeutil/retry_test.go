@@ -0,0 +1,142 @@
+package kubeutil_test
+
+import (
+	"context"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/saholman/aerospike-code-challenge/pkg/kubeutil"
+)
+
+// failNTimes returns a reactor that fails the first n matching calls with
+// err, then defers to the fake clientset's normal object-tracker behavior.
+func failNTimes(n int, err error) k8stesting.ReactionFunc {
+	calls := 0
+	return func(action k8stesting.Action) (bool, runtime.Object, error) {
+		calls++
+		if calls <= n {
+			return true, nil, err
+		}
+		return false, nil, nil
+	}
+}
+
+func TestCreateWithRetry(t *testing.T) {
+	tests := []struct {
+		name      string
+		failures  int
+		failure   error
+		wantErr   bool
+		wantCalls int
+	}{
+		{
+			name:      "succeeds on first attempt",
+			failures:  0,
+			failure:   apierrors.NewServerTimeout(schema.GroupResource{Resource: "pods"}, "create", 1),
+			wantCalls: 1,
+		},
+		{
+			name:      "retries through transient server timeouts",
+			failures:  2,
+			failure:   apierrors.NewServerTimeout(schema.GroupResource{Resource: "pods"}, "create", 1),
+			wantCalls: 3,
+		},
+		{
+			name:      "retries through too many requests",
+			failures:  1,
+			failure:   apierrors.NewTooManyRequests("rate limited", 1),
+			wantCalls: 2,
+		},
+		{
+			name:      "surfaces already-exists immediately",
+			failures:  10,
+			failure:   apierrors.NewAlreadyExists(schema.GroupResource{Resource: "pods"}, "hello-world"),
+			wantErr:   true,
+			wantCalls: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clientset := fake.NewSimpleClientset()
+			clientset.PrependReactor("create", "pods", failNTimes(tt.failures, tt.failure))
+
+			calls := 0
+			pod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "hello-world", Namespace: "aerospike"}}
+
+			got, err := kubeutil.CreateWithRetry(context.Background(), func(ctx context.Context) (*v1.Pod, error) {
+				calls++
+				return clientset.CoreV1().Pods("aerospike").Create(ctx, pod, metav1.CreateOptions{})
+			})
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CreateWithRetry() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if calls != tt.wantCalls {
+				t.Fatalf("CreateWithRetry() made %d calls, want %d", calls, tt.wantCalls)
+			}
+			if !tt.wantErr && got.Name != pod.Name {
+				t.Fatalf("CreateWithRetry() = %+v, want %+v", got, pod)
+			}
+		})
+	}
+}
+
+func TestDeleteWithRetry(t *testing.T) {
+	tests := []struct {
+		name      string
+		failures  int
+		failure   error
+		wantErr   bool
+		wantCalls int
+	}{
+		{
+			name:      "retries through an internal error",
+			failures:  2,
+			failure:   apierrors.NewInternalError(assertionError{}),
+			wantCalls: 3,
+		},
+		{
+			name:      "surfaces not-found immediately",
+			failures:  10,
+			failure:   apierrors.NewNotFound(schema.GroupResource{Resource: "pods"}, "hello-world"),
+			wantErr:   true,
+			wantCalls: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clientset := fake.NewSimpleClientset(&v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "hello-world", Namespace: "aerospike"},
+			})
+			clientset.PrependReactor("delete", "pods", failNTimes(tt.failures, tt.failure))
+
+			calls := 0
+			err := kubeutil.DeleteWithRetry(context.Background(), func(ctx context.Context) error {
+				calls++
+				return clientset.CoreV1().Pods("aerospike").Delete(ctx, "hello-world", metav1.DeleteOptions{})
+			})
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DeleteWithRetry() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if calls != tt.wantCalls {
+				t.Fatalf("DeleteWithRetry() made %d calls, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+// assertionError is a minimal error used to build an IsInternalError
+// without depending on a real apiserver error payload.
+type assertionError struct{}
+
+func (assertionError) Error() string { return "boom" }
@@ -0,0 +1,86 @@
+// Package manifest loads the YAML/JSON manifests that describe the
+// cluster's desired resources, either from the binary's embedded default
+// set or from an arbitrary filesystem.
+package manifest
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	yamlutil "k8s.io/apimachinery/pkg/util/yaml"
+)
+
+//go:embed default
+var defaultManifests embed.FS
+
+// Default returns the controller's built-in desired resources applied
+// generically through the dynamic client: currently just the aerospike
+// namespace.
+func Default() ([]*unstructured.Unstructured, error) {
+	return LoadFS(defaultManifests, "default")
+}
+
+// LoadFS reads every .yaml/.yml/.json file in dir (non-recursive, sorted
+// by name) and decodes it into a list of unstructured objects. Files may
+// contain multiple "---"-separated YAML documents.
+func LoadFS(fsys fs.FS, dir string) ([]*unstructured.Unstructured, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest dir %s: %w", dir, err)
+	}
+
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		switch path.Ext(entry.Name()) {
+		case ".yaml", ".yml", ".json":
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+
+	var manifests []*unstructured.Unstructured
+	for _, name := range names {
+		data, err := fs.ReadFile(fsys, path.Join(dir, name))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read manifest %s: %w", name, err)
+		}
+
+		objs, err := decodeAll(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode manifest %s: %w", name, err)
+		}
+		manifests = append(manifests, objs...)
+	}
+
+	return manifests, nil
+}
+
+func decodeAll(data []byte) ([]*unstructured.Unstructured, error) {
+	decoder := yamlutil.NewYAMLOrJSONDecoder(bytes.NewReader(data), 4096)
+
+	var objs []*unstructured.Unstructured
+	for {
+		obj := map[string]any{}
+		if err := decoder.Decode(&obj); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, err
+		}
+		if len(obj) == 0 {
+			continue
+		}
+		objs = append(objs, &unstructured.Unstructured{Object: obj})
+	}
+
+	return objs, nil
+}
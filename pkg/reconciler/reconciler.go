@@ -0,0 +1,133 @@
+// Package reconciler applies the Aerospike Code Challenge's desired
+// cluster state from its manifests and cleans it back up again.
+package reconciler
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/saholman/aerospike-code-challenge/pkg/apply"
+	"github.com/saholman/aerospike-code-challenge/pkg/kube"
+	"github.com/saholman/aerospike-code-challenge/pkg/kubeutil"
+	"github.com/saholman/aerospike-code-challenge/pkg/manifest"
+)
+
+const (
+	namespaceName  = "aerospike"
+	helloWorldName = "hello-world"
+)
+
+// helloWorldLabels identifies the hello-world pod Run creates, so Cleanup
+// can find and remove it without needing to know its generated name.
+var helloWorldLabels = map[string]string{"app": helloWorldName}
+
+type SimplePod struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+}
+
+func SimplePodFromPod(pod v1.Pod) SimplePod {
+	return SimplePod{
+		Name:      pod.ObjectMeta.Name,
+		Namespace: pod.ObjectMeta.Namespace,
+	}
+}
+
+// Run applies the controller's desired resources and logs a snapshot of
+// cluster state. dynClient is used for the manifest apply; clientset is
+// used for the diagnostic namespace/pod listing below, and for creating
+// the hello-world pod via kube.CreatePod.
+func Run(ctx context.Context, clientset kubernetes.Interface, dynClient dynamic.Interface) error {
+	// Print out all namespaces
+	namespaceList, err := kubeutil.GetWithRetry(ctx, func(ctx context.Context) (*v1.NamespaceList, error) {
+		return clientset.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list namespaces: %w", err)
+	}
+	namespaces := make([]string, 0, len(namespaceList.Items))
+	for _, ns := range namespaceList.Items {
+		namespaces = append(namespaces, ns.Name)
+	}
+	log.Info().Interface("namespaces", namespaces).Msg("k8s namespaces")
+
+	if _, err := kube.EnsureNamespace(ctx, clientset, namespaceName, nil, nil); err != nil {
+		return fmt.Errorf("failed to ensure %s namespace: %w", namespaceName, err)
+	}
+	log.Info().Msgf("ensured %s namespace", namespaceName)
+
+	manifests, err := manifest.Default()
+	if err != nil {
+		return fmt.Errorf("failed to load default manifests: %w", err)
+	}
+
+	for _, result := range apply.Reconcile(ctx, clientset, dynClient, manifests) {
+		if result.Err != nil {
+			return fmt.Errorf("failed to apply %s %s/%s: %w", result.GVK.Kind, result.Namespace, result.Name, result.Err)
+		}
+		log.Info().
+			Str("kind", result.GVK.Kind).
+			Str("namespace", result.Namespace).
+			Str("name", result.Name).
+			Str("outcome", string(result.Outcome)).
+			Msg("applied manifest")
+	}
+
+	pod, err := kube.CreatePod(ctx, clientset, kube.PodOptions{
+		Namespace:    namespaceName,
+		GenerateName: helloWorldName + "-",
+		Image:        helloWorldName,
+		Labels:       helloWorldLabels,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create %s pod: %w", helloWorldName, err)
+	}
+	log.Info().Str("namespace", pod.Namespace).Str("name", pod.Name).Msg("created hello-world pod")
+
+	// Print out pod names and the namespaces they are in for any pods that have given label
+	label := "k8s-app=kube-dns"
+	podList, err := kubeutil.GetWithRetry(ctx, func(ctx context.Context) (*v1.PodList, error) {
+		return clientset.CoreV1().Pods("").List(ctx, metav1.ListOptions{
+			LabelSelector: label,
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list pods with label %s: %w", label, err)
+	}
+	pods := make([]SimplePod, 0, len(podList.Items))
+	for _, pod := range podList.Items {
+		pods = append(pods, SimplePodFromPod(pod))
+	}
+	log.Info().Interface("pods", pods).Msgf("pods with label %s", label)
+	return nil
+}
+
+// Cleanup removes the resources Run applies.
+func Cleanup(ctx context.Context, clientset kubernetes.Interface, dynClient dynamic.Interface) error {
+	manifests, err := manifest.Default()
+	if err != nil {
+		return fmt.Errorf("failed to load default manifests: %w", err)
+	}
+
+	if err := apply.Delete(ctx, dynClient, manifests); err != nil {
+		return fmt.Errorf("failed to delete manifests: %w", err)
+	}
+	log.Info().Msg("deleted default manifests")
+
+	labelSelector := fmt.Sprintf("app=%s", helloWorldName)
+	if err := kubeutil.DeleteWithRetry(ctx, func(ctx context.Context) error {
+		return clientset.CoreV1().Pods(namespaceName).DeleteCollection(ctx, metav1.DeleteOptions{}, metav1.ListOptions{
+			LabelSelector: labelSelector,
+		})
+	}); err != nil {
+		return fmt.Errorf("failed to delete %s pod: %w", helloWorldName, err)
+	}
+	log.Info().Msg("deleted hello-world pod")
+	return nil
+}
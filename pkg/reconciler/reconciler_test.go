@@ -0,0 +1,94 @@
+package reconciler_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/saholman/aerospike-code-challenge/pkg/reconciler"
+)
+
+var (
+	namespacesGVR = schema.GroupVersionResource{Version: "v1", Resource: "namespaces"}
+	podsGVR       = schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+)
+
+func newDynClient() dynamic.Interface {
+	return dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		namespacesGVR: "NamespaceList",
+		podsGVR:       "PodList",
+	})
+}
+
+var _ = Describe("Reconciler", func() {
+	When("Run is called", func() {
+		var (
+			clientset kubernetes.Interface
+			dynClient dynamic.Interface
+			ctx       context.Context
+		)
+
+		BeforeEach(func() {
+			ctx = context.Background()
+			clientset = fake.NewSimpleClientset()
+			dynClient = newDynClient()
+
+			err := reconciler.Run(ctx, clientset, dynClient)
+			Expect(err).ShouldNot(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			err := reconciler.Cleanup(ctx, clientset, dynClient)
+			Expect(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should have created the aerospike namespace", func() {
+			ns, err := dynClient.Resource(namespacesGVR).Get(ctx, "aerospike", metav1.GetOptions{})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(ns.GetName()).To(Equal("aerospike"))
+		})
+
+		It("should have created the hello-world pod without waiting for it to become ready", func() {
+			// hello-world runs to completion and never reports
+			// PodReady, so Run must not block on it.
+			pods, err := clientset.CoreV1().Pods("aerospike").List(ctx, metav1.ListOptions{
+				LabelSelector: "app=hello-world",
+			})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(pods.Items).To(HaveLen(1))
+
+			pod := pods.Items[0]
+			Expect(pod.Spec.Containers).To(HaveLen(1))
+			Expect(pod.Spec.Containers[0].Image).To(Equal("hello-world"))
+		})
+	})
+
+	When("the aerospike namespace already exists", func() {
+		It("still succeeds", func() {
+			ctx := context.Background()
+			clientset := fake.NewSimpleClientset(&v1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{Name: "aerospike"},
+			})
+			dynClient := newDynClient()
+
+			err := reconciler.Run(ctx, clientset, dynClient)
+			Expect(err).ShouldNot(HaveOccurred())
+			defer func() {
+				Expect(reconciler.Cleanup(ctx, clientset, dynClient)).To(Succeed())
+			}()
+
+			ns, err := clientset.CoreV1().Namespaces().Get(ctx, "aerospike", metav1.GetOptions{})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(ns.Name).To(Equal("aerospike"))
+		})
+	})
+})